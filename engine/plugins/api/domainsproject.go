@@ -24,18 +24,26 @@ import (
 )
 
 type domainsProject struct {
-	name   string
-	log    *slog.Logger
-	rlimit *rate.Limiter
-	source *et.Source
+	name    string
+	log     *slog.Logger
+	rlimit  *rate.Limiter
+	source  *et.Source
+	metrics et.MetricsManager
+	pools   *support.SessionCredentialPools
 }
 
-func NewDomainsProject() et.Plugin {
+// NewDomainsProject builds the plugin. metrics records its observable
+// points (requests, discoveries, cache hits, credential health) instead of
+// the plugin reaching for a package-level global, so a caller running more
+// than one registry or session in the same process gets independent counts.
+func NewDomainsProject(metrics et.MetricsManager) et.Plugin {
 	limit := rate.Every(2 * time.Second)
 
 	return &domainsProject{
-		name:   "DomainsProject",
-		rlimit: rate.NewLimiter(limit, 1),
+		name:    "DomainsProject",
+		rlimit:  rate.NewLimiter(limit, 1),
+		metrics: metrics,
+		pools:   support.NewSessionCredentialPools("DomainsProject"),
 		source: &et.Source{
 			Name:       "DomainsProject",
 			Confidence: 80,
@@ -83,6 +91,7 @@ func (dp *domainsProject) check(e *et.Event) error {
 	if ds == nil || len(ds.Creds) == 0 {
 		return nil
 	}
+	pool := dp.credentialPool(e.Session, ds)
 
 	since, err := support.TTLStartTime(e.Session.Config(), string(oam.FQDN), string(oam.FQDN), dp.name)
 	if err != nil {
@@ -91,9 +100,11 @@ func (dp *domainsProject) check(e *et.Event) error {
 
 	var names []*dbt.Entity
 	if support.AssetMonitoredWithinTTL(e.Session, e.Entity, dp.source, since) {
+		dp.metrics.CacheHit(dp.name)
 		names = append(names, dp.lookup(e, fqdn.Name, since)...)
 	} else {
-		names = append(names, dp.query(e, fqdn.Name, ds)...)
+		dp.metrics.LiveQuery(dp.name)
+		names = append(names, dp.query(e, fqdn.Name, pool)...)
 		support.MarkAssetMonitored(e.Session, e.Entity, dp.source)
 	}
 
@@ -107,15 +118,33 @@ func (dp *domainsProject) lookup(e *et.Event, name string, since time.Time) []*d
 	return support.SourceToAssetsWithinTTL(e.Session, name, string(oam.FQDN), dp.source, since)
 }
 
-func (dp *domainsProject) query(e *et.Event, name string, ds *config.DataSource) []*dbt.Entity {
+// credentialPool returns the CredentialPool for s, building one the first
+// time s is seen. Pools are kept per session, not on the plugin itself,
+// since two sessions can configure different credentials for the same
+// source and the plugin instance is shared across every concurrent session.
+// dp.pools evicts sessions that have gone idle, so this does not grow
+// without bound over the plugin's lifetime.
+func (dp *domainsProject) credentialPool(s et.Session, ds *config.DataSource) *support.CredentialPool {
+	return dp.pools.Get(s, ds.Creds, dp.log)
+}
+
+func (dp *domainsProject) query(e *et.Event, name string, pool *support.CredentialPool) []*dbt.Entity {
 	var names []string
 
-	for _, cr := range ds.Creds {
-		if cr == nil || cr.Username == "" || cr.Password == "" {
-			continue
+	// Bound attempts by the number of registered credentials: Next can keep
+	// returning a usable key indefinitely, so this loop must not rely on the
+	// pool itself to terminate it.
+	for attempts, max := 0, pool.Len(); attempts < max; attempts++ {
+		cr, ok := pool.Next()
+		if !ok {
+			break
 		}
 
+		waitStart := time.Now()
 		_ = dp.rlimit.Wait(context.TODO())
+		dp.metrics.RateLimitWait(dp.name, time.Since(waitStart))
+
+		dp.metrics.RequestIssued(dp.name)
 		resp, err := http.RequestWebPage(context.TODO(), &http.Request{
 			URL:    "https://api.domainsproject.org/api/tld/search?domain=" + name,
 			Header: http.Header{"Accept": []string{"application/json"}},
@@ -124,6 +153,14 @@ func (dp *domainsProject) query(e *et.Event, name string, ds *config.DataSource)
 				Password: cr.Password,
 			},
 		})
+		pool.Update(cr, resp, err)
+		if resp != nil {
+			dp.metrics.HTTPStatus(dp.name, resp.StatusCode)
+			dp.metrics.BytesReceived(dp.name, len(resp.Body))
+			if resp.StatusCode == 401 || resp.StatusCode == 403 || resp.StatusCode == 429 {
+				dp.metrics.AuthFailure(dp.name, cr.Username)
+			}
+		}
 		if err != nil || resp.Body == "" {
 			continue
 		}
@@ -134,16 +171,21 @@ func (dp *domainsProject) query(e *et.Event, name string, ds *config.DataSource)
 		}
 
 		if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
+			dp.metrics.UnmarshalError(dp.name)
 			continue
 		}
+		dp.metrics.SubdomainsDiscovered(dp.name, len(result.Domains))
 
+		var inScope int
 		for _, s := range result.Domains {
 			subdomain := strings.ToLower(strings.TrimSpace(dns.RemoveAsteriskLabel(s)))
 			// if the subdomain is not in scope, skip it
 			if _, conf := e.Session.Scope().IsAssetInScope(&oamdns.FQDN{Name: subdomain}, 0); conf > 0 {
 				names = append(names, subdomain)
+				inScope++
 			}
 		}
+		dp.metrics.SubdomainsInScope(dp.name, inScope)
 		break
 	}
 
@@ -0,0 +1,152 @@
+// Copyright © by Jeff Foley 2017-2025. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package generic drives passive DNS / subdomain API sources from a YAML
+// descriptor instead of a hand-written plugin. Most API plugins in
+// engine/plugins/api follow the same shape: rate limit, HTTP GET with some
+// auth style, unmarshal JSON at a fixed field, filter through scope, store
+// FQDNs. Descriptor captures that shape so contributors can add a new
+// source without touching Go code.
+package generic
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AuthStyle identifies how credentials are attached to a request.
+type AuthStyle string
+
+const (
+	AuthNone       AuthStyle = "none"
+	AuthBasic      AuthStyle = "basic"
+	AuthBearer     AuthStyle = "bearer"
+	AuthHeader     AuthStyle = "header"
+	AuthQueryParam AuthStyle = "query_param"
+)
+
+// AuthConfig describes how a Descriptor's credentials are applied to a
+// request. Header and QueryParam name the field used when Style requires
+// one, e.g. "apikey" or "X-Api-Key".
+type AuthConfig struct {
+	Style      AuthStyle `yaml:"style"`
+	Header     string    `yaml:"header,omitempty"`
+	QueryParam string    `yaml:"query_param,omitempty"`
+}
+
+// PaginationConfig describes how successive pages are requested. Param
+// names the URL template placeholder the page number is substituted into,
+// e.g. a Param of "page" is substituted into "{page}"; it defaults to
+// "page" when left empty. MaxPages caps how many pages are fetched per
+// query so a misbehaving source cannot loop forever. A nil Pagination on a
+// Descriptor means the source is not paged.
+type PaginationConfig struct {
+	Param     string `yaml:"param"`
+	StartPage int    `yaml:"start_page"`
+	MaxPages  int    `yaml:"max_pages"`
+}
+
+// Descriptor is the YAML-defined shape of a single data source. URL is a
+// template containing the {domain} placeholder, and {page} when Pagination
+// is set. ResultPath is a dot-separated path (e.g. "data.subdomains") to the
+// JSON array of subdomain strings in the response body; Regex is used
+// instead of ResultPath for sources that return HTML or other non-JSON
+// bodies.
+type Descriptor struct {
+	Name             string            `yaml:"name"`
+	URL              string            `yaml:"url"`
+	Confidence       int               `yaml:"confidence"`
+	RateLimitSeconds float64           `yaml:"rate_limit_seconds"`
+	Auth             AuthConfig        `yaml:"auth"`
+	Pagination       *PaginationConfig `yaml:"pagination,omitempty"`
+	ResultPath       string            `yaml:"result_path,omitempty"`
+	Regex            string            `yaml:"regex,omitempty"`
+}
+
+// Validate reports the first problem that would prevent the Descriptor from
+// being turned into a working plugin. An unset auth.style is set to
+// AuthNone.
+func (d *Descriptor) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("descriptor is missing a name")
+	}
+	if d.URL == "" {
+		return fmt.Errorf("descriptor %s is missing a url", d.Name)
+	}
+	if d.ResultPath == "" && d.Regex == "" {
+		return fmt.Errorf("descriptor %s must set result_path or regex", d.Name)
+	}
+	if d.Regex != "" {
+		if _, err := regexp.Compile(d.Regex); err != nil {
+			return fmt.Errorf("descriptor %s has an invalid regex: %w", d.Name, err)
+		}
+	}
+	if d.Auth.Style == "" {
+		d.Auth.Style = AuthNone
+	}
+	switch d.Auth.Style {
+	case AuthNone, AuthBasic, AuthBearer, AuthHeader, AuthQueryParam:
+	default:
+		return fmt.Errorf("descriptor %s has unknown auth.style %q", d.Name, d.Auth.Style)
+	}
+	return nil
+}
+
+// LoadDescriptors reads every *.yaml/*.yml file in dir and returns the
+// Descriptors they define. A file that fails to parse or validate is
+// skipped with an error describing the offending file rather than aborting
+// the whole directory. A dir that does not exist is not an error; it
+// simply yields no Descriptors, since a deployment with no generic sources
+// configured need not create the directory.
+func LoadDescriptors(dir string) ([]*Descriptor, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var descs []*Descriptor
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+
+		var d Descriptor
+		if err := yaml.Unmarshal(data, &d); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		if err := d.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+
+		descs = append(descs, &d)
+	}
+
+	if len(errs) > 0 {
+		return descs, fmt.Errorf("generic: %d descriptor(s) failed to load: %w", len(errs), errors.Join(errs...))
+	}
+	return descs, nil
+}
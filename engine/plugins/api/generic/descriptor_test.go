@@ -0,0 +1,96 @@
+// Copyright © by Jeff Foley 2017-2025. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package generic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDescriptorValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		desc    Descriptor
+		wantErr bool
+	}{
+		{"valid", Descriptor{Name: "x", URL: "https://example.com/{domain}", ResultPath: "data", Auth: AuthConfig{Style: AuthNone}}, false},
+		{"missing name", Descriptor{URL: "https://example.com", ResultPath: "data"}, true},
+		{"missing url", Descriptor{Name: "x", ResultPath: "data"}, true},
+		{"missing result_path and regex", Descriptor{Name: "x", URL: "https://example.com"}, true},
+		{"regex instead of result_path", Descriptor{Name: "x", URL: "https://example.com", Regex: "(.+)"}, false},
+		{"unknown auth style", Descriptor{Name: "x", URL: "https://example.com", ResultPath: "data", Auth: AuthConfig{Style: "token"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.desc.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadDescriptorsMissingDirIsNotAnError(t *testing.T) {
+	descs, err := LoadDescriptors(filepath.Join(t.TempDir(), "does-not-exist"))
+	if err != nil {
+		t.Fatalf("LoadDescriptors() error = %v, want nil", err)
+	}
+	if descs != nil {
+		t.Fatalf("LoadDescriptors() = %v, want nil", descs)
+	}
+}
+
+func TestLoadDescriptorsParsesValidFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "good.yaml", `
+name: good-source
+url: "https://example.com/{domain}"
+result_path: data
+auth:
+  style: none
+`)
+	writeFile(t, dir, "ignored.txt", "not a descriptor")
+
+	descs, err := LoadDescriptors(dir)
+	if err != nil {
+		t.Fatalf("LoadDescriptors() error = %v", err)
+	}
+	if len(descs) != 1 {
+		t.Fatalf("len(descs) = %d, want 1", len(descs))
+	}
+	if descs[0].Name != "good-source" {
+		t.Fatalf("Name = %q, want good-source", descs[0].Name)
+	}
+}
+
+func TestLoadDescriptorsAggregatesErrorsWithoutAbortingTheDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "good.yaml", `
+name: good-source
+url: "https://example.com/{domain}"
+result_path: data
+`)
+	writeFile(t, dir, "bad.yaml", `
+url: "https://example.com"
+result_path: data
+`)
+
+	descs, err := LoadDescriptors(dir)
+	if err == nil {
+		t.Fatal("LoadDescriptors() error = nil, want the bad.yaml failure")
+	}
+	if len(descs) != 1 {
+		t.Fatalf("len(descs) = %d, want 1 (good.yaml should still load)", len(descs))
+	}
+}
+
+func writeFile(t *testing.T, dir, name, contents string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright © by Jeff Foley 2017-2025. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package generic
+
+import (
+	"fmt"
+
+	et "github.com/owasp-amass/amass/v5/engine/types"
+)
+
+// LoadPlugins reads every descriptor in dir and returns the et.Plugin for
+// each one. Callers add the result to the same slice of plugins that
+// NewDomainsProject and friends are appended to during engine startup; each
+// returned Plugin wires itself into the registry via Start like any other.
+func LoadPlugins(dir string) ([]et.Plugin, error) {
+	descs, err := LoadDescriptors(dir)
+	if err != nil && len(descs) == 0 {
+		return nil, err
+	}
+
+	var plugins []et.Plugin
+	for _, d := range descs {
+		p, perr := NewSource(d)
+		if perr != nil {
+			return plugins, fmt.Errorf("generic: %s: %w", d.Name, perr)
+		}
+		plugins = append(plugins, p)
+	}
+
+	return plugins, err
+}
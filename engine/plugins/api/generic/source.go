@@ -0,0 +1,346 @@
+// Copyright © by Jeff Foley 2017-2025. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/amass/v5/config"
+	"github.com/owasp-amass/amass/v5/engine/plugins/support"
+	et "github.com/owasp-amass/amass/v5/engine/types"
+	"github.com/owasp-amass/amass/v5/internal/net/dns"
+	"github.com/owasp-amass/amass/v5/internal/net/http"
+	dbt "github.com/owasp-amass/asset-db/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/dns"
+	"golang.org/x/time/rate"
+)
+
+// source is an et.Plugin driven entirely by a Descriptor. It wires itself
+// into the registry the same way the hand-written API plugins do, so a
+// YAML-defined source behaves identically to one compiled into the binary.
+type source struct {
+	name   string
+	log    *slog.Logger
+	rlimit *rate.Limiter
+	desc   *Descriptor
+	regex  *regexp.Regexp
+	source *et.Source
+	pools  *support.SessionCredentialPools
+}
+
+// NewSource builds an et.Plugin from a Descriptor.
+func NewSource(desc *Descriptor) (et.Plugin, error) {
+	if err := desc.Validate(); err != nil {
+		return nil, err
+	}
+
+	var re *regexp.Regexp
+	if desc.Regex != "" {
+		compiled, err := regexp.Compile(desc.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("descriptor %s: %w", desc.Name, err)
+		}
+		re = compiled
+	}
+
+	interval := time.Second
+	if desc.RateLimitSeconds > 0 {
+		interval = time.Duration(desc.RateLimitSeconds * float64(time.Second))
+	}
+
+	confidence := desc.Confidence
+	if confidence == 0 {
+		confidence = 60
+	}
+
+	return &source{
+		name:   desc.Name,
+		rlimit: rate.NewLimiter(rate.Every(interval), 1),
+		desc:   desc,
+		regex:  re,
+		pools:  support.NewSessionCredentialPools(desc.Name),
+		source: &et.Source{
+			Name:       desc.Name,
+			Confidence: confidence,
+		},
+	}, nil
+}
+
+func (s *source) Name() string {
+	return s.name
+}
+
+func (s *source) Start(r et.Registry) error {
+	s.log = r.Log().WithGroup("plugin").With("name", s.name)
+
+	if err := r.RegisterHandler(&et.Handler{
+		Plugin:     s,
+		Name:       s.name + "-Handler",
+		Priority:   9,
+		Transforms: []string{string(oam.FQDN)},
+		EventType:  oam.FQDN,
+		Callback:   s.check,
+	}); err != nil {
+		return err
+	}
+
+	s.log.Info("Plugin started")
+	return nil
+}
+
+func (s *source) Stop() {
+	s.log.Info("Plugin stopped")
+}
+
+func (s *source) check(e *et.Event) error {
+	fqdn, ok := e.Entity.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	if !support.HasSLDInScope(e) {
+		return nil
+	}
+
+	ds := e.Session.Config().GetDataSourceConfig(s.name)
+	if s.desc.Auth.Style != AuthNone && (ds == nil || len(ds.Creds) == 0) {
+		return nil
+	}
+
+	var pool *support.CredentialPool
+	if s.desc.Auth.Style != AuthNone {
+		pool = s.credentialPool(e.Session, ds)
+	}
+
+	since, err := support.TTLStartTime(e.Session.Config(), string(oam.FQDN), string(oam.FQDN), s.name)
+	if err != nil {
+		return err
+	}
+
+	var names []*dbt.Entity
+	if support.AssetMonitoredWithinTTL(e.Session, e.Entity, s.source, since) {
+		names = append(names, s.lookup(e, fqdn.Name, since)...)
+	} else {
+		names = append(names, s.query(e, fqdn.Name, pool)...)
+		support.MarkAssetMonitored(e.Session, e.Entity, s.source)
+	}
+
+	if len(names) > 0 {
+		s.process(e, names)
+	}
+	return nil
+}
+
+func (s *source) lookup(e *et.Event, name string, since time.Time) []*dbt.Entity {
+	return support.SourceToAssetsWithinTTL(e.Session, name, string(oam.FQDN), s.source, since)
+}
+
+// credentialPool returns the CredentialPool for sess, building one the first
+// time sess is seen. Pools are kept per session, not on the plugin itself,
+// since two sessions can configure different credentials for the same
+// source and the plugin instance is shared across every concurrent session.
+// s.pools evicts sessions that have gone idle, so this does not grow
+// without bound over the plugin's lifetime.
+func (s *source) credentialPool(sess et.Session, ds *config.DataSource) *support.CredentialPool {
+	return s.pools.Get(sess, ds.Creds, s.log)
+}
+
+func (s *source) query(e *et.Event, name string, pool *support.CredentialPool) []*dbt.Entity {
+	var names []string
+
+	maxPages := 1
+	if s.desc.Pagination != nil && s.desc.Pagination.MaxPages > 0 {
+		maxPages = s.desc.Pagination.MaxPages
+	}
+
+	for page := s.startPage(); page < s.startPage()+maxPages; page++ {
+		found, more := s.fetchPage(e, name, page, pool)
+		names = append(names, found...)
+		if !more {
+			break
+		}
+	}
+
+	return s.store(e, names)
+}
+
+func (s *source) startPage() int {
+	if s.desc.Pagination != nil {
+		return s.desc.Pagination.StartPage
+	}
+	return 0
+}
+
+// fetchPage issues a request for page and returns the in-scope subdomains
+// it found, along with whether a further page should be attempted. pool is
+// nil for descriptors whose Auth.Style is AuthNone. When pool is set,
+// fetchPage advances to the next credential and retries on failure, bounded
+// by pool.Len() attempts, the same as domainsProject.query: Next can keep
+// returning a usable credential indefinitely, so the loop must not rely on
+// the pool itself to terminate it.
+func (s *source) fetchPage(e *et.Event, name string, page int, pool *support.CredentialPool) ([]string, bool) {
+	attempts := 1
+	if pool != nil {
+		attempts = pool.Len()
+	}
+
+	for ; attempts > 0; attempts-- {
+		req := &http.Request{URL: s.buildURL(name, page), Header: http.Header{"Accept": []string{"application/json"}}}
+
+		var cred *config.Credential
+		if pool != nil {
+			cr, ok := pool.Next()
+			if !ok {
+				return nil, false
+			}
+			cred = cr
+			s.applyAuth(req, cred)
+		}
+
+		_ = s.rlimit.Wait(context.TODO())
+		resp, err := http.RequestWebPage(context.TODO(), req)
+		if pool != nil {
+			pool.Update(cred, resp, err)
+		}
+		if err != nil || resp.Body == "" {
+			continue
+		}
+
+		raw, err := s.extract(resp.Body)
+		if err != nil {
+			s.log.Error("failed to extract results", "err", err.Error())
+			return nil, false
+		}
+
+		var names []string
+		for _, v := range raw {
+			subdomain := strings.ToLower(strings.TrimSpace(dns.RemoveAsteriskLabel(v)))
+			if _, conf := e.Session.Scope().IsAssetInScope(&oamdns.FQDN{Name: subdomain}, 0); conf > 0 {
+				names = append(names, subdomain)
+			}
+		}
+
+		return names, s.desc.Pagination != nil && len(raw) > 0
+	}
+
+	return nil, false
+}
+
+func (s *source) buildURL(name string, page int) string {
+	url := strings.ReplaceAll(s.desc.URL, "{domain}", name)
+	if p := s.desc.Pagination; p != nil {
+		param := p.Param
+		if param == "" {
+			param = "page"
+		}
+		url = strings.ReplaceAll(url, "{"+param+"}", strconv.Itoa(page))
+	}
+	return url
+}
+
+func (s *source) applyAuth(req *http.Request, cred *config.Credential) {
+	if cred == nil {
+		return
+	}
+
+	switch s.desc.Auth.Style {
+	case AuthBasic:
+		req.Auth = &http.BasicAuth{Username: cred.Username, Password: cred.Password}
+	case AuthBearer:
+		req.Header.Set("Authorization", "Bearer "+cred.Password)
+	case AuthHeader:
+		name := s.desc.Auth.Header
+		if name == "" {
+			name = "Authorization"
+		}
+		req.Header.Set(name, cred.Password)
+	case AuthQueryParam:
+		sep := "?"
+		if strings.Contains(req.URL, "?") {
+			sep = "&"
+		}
+		param := s.desc.Auth.QueryParam
+		if param == "" {
+			param = "apikey"
+		}
+		req.URL += sep + param + "=" + url.QueryEscape(cred.Password)
+	}
+}
+
+// extract pulls the subdomain strings out of body, using ResultPath for a
+// JSON response or falling back to Regex for everything else.
+func (s *source) extract(body string) ([]string, error) {
+	if s.desc.ResultPath != "" {
+		var doc interface{}
+		if err := json.Unmarshal([]byte(body), &doc); err != nil {
+			if s.regex == nil {
+				return nil, err
+			}
+		} else {
+			return valuesAtPath(doc, strings.Split(s.desc.ResultPath, "."))
+		}
+	}
+
+	if s.regex == nil {
+		return nil, errors.New("no result_path or regex configured")
+	}
+
+	var names []string
+	for _, m := range s.regex.FindAllStringSubmatch(body, -1) {
+		if len(m) > 1 {
+			names = append(names, m[1])
+		} else {
+			names = append(names, m[0])
+		}
+	}
+	return names, nil
+}
+
+// valuesAtPath walks doc following path and returns the leaf array as a
+// slice of strings.
+func valuesAtPath(doc interface{}, path []string) ([]string, error) {
+	cur := doc
+	for _, key := range path {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("result_path segment %q is not an object", key)
+		}
+		cur, ok = m[key]
+		if !ok {
+			return nil, fmt.Errorf("result_path segment %q not found", key)
+		}
+	}
+
+	arr, ok := cur.([]interface{})
+	if !ok {
+		return nil, errors.New("result_path does not resolve to an array")
+	}
+
+	var names []string
+	for _, v := range arr {
+		if str, ok := v.(string); ok {
+			names = append(names, str)
+		}
+	}
+	return names, nil
+}
+
+func (s *source) store(e *et.Event, names []string) []*dbt.Entity {
+	return support.StoreFQDNsWithSource(e.Session, names, s.source, s.name, s.name+"-Handler")
+}
+
+func (s *source) process(e *et.Event, assets []*dbt.Entity) {
+	support.ProcessFQDNsWithSource(e, assets, s.source)
+}
@@ -0,0 +1,97 @@
+// Copyright © by Jeff Foley 2017-2025. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package generic
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/owasp-amass/amass/v5/config"
+	"github.com/owasp-amass/amass/v5/internal/net/http"
+)
+
+func TestBuildURLDefaultsPaginationParamToPage(t *testing.T) {
+	s := &source{desc: &Descriptor{
+		URL:        "https://example.com/{domain}?page={page}",
+		Pagination: &PaginationConfig{},
+	}}
+
+	if got := s.buildURL("example.com", 2); got != "https://example.com/example.com?page=2" {
+		t.Fatalf("buildURL() = %q", got)
+	}
+}
+
+func TestBuildURLHonorsCustomPaginationParam(t *testing.T) {
+	s := &source{desc: &Descriptor{
+		URL:        "https://example.com/{domain}?offset={offset}",
+		Pagination: &PaginationConfig{Param: "offset"},
+	}}
+
+	if got := s.buildURL("example.com", 3); got != "https://example.com/example.com?offset=3" {
+		t.Fatalf("buildURL() = %q", got)
+	}
+}
+
+func TestBuildURLNoPagination(t *testing.T) {
+	s := &source{desc: &Descriptor{URL: "https://example.com/{domain}"}}
+
+	if got := s.buildURL("example.com", 5); got != "https://example.com/example.com" {
+		t.Fatalf("buildURL() = %q", got)
+	}
+}
+
+func TestApplyAuthQueryParamEscapesValue(t *testing.T) {
+	s := &source{desc: &Descriptor{Auth: AuthConfig{Style: AuthQueryParam, QueryParam: "key"}}}
+	req := &http.Request{URL: "https://example.com/x"}
+
+	s.applyAuth(req, &config.Credential{Username: "u", Password: "a b&c"})
+
+	if req.URL != "https://example.com/x?key=a+b%26c" {
+		t.Fatalf("applyAuth() URL = %q", req.URL)
+	}
+}
+
+func TestApplyAuthBearer(t *testing.T) {
+	s := &source{desc: &Descriptor{Auth: AuthConfig{Style: AuthBearer}}}
+	req := &http.Request{Header: http.Header{}}
+
+	s.applyAuth(req, &config.Credential{Password: "token123"})
+
+	if got := req.Header.Get("Authorization"); got != "Bearer token123" {
+		t.Fatalf("Authorization header = %q", got)
+	}
+}
+
+func TestExtractByResultPath(t *testing.T) {
+	s := &source{desc: &Descriptor{ResultPath: "data.subdomains"}}
+
+	names, err := s.extract(`{"data":{"subdomains":["a.example.com","b.example.com"]}}`)
+	if err != nil {
+		t.Fatalf("extract() error = %v", err)
+	}
+	if len(names) != 2 || names[0] != "a.example.com" {
+		t.Fatalf("extract() = %v", names)
+	}
+}
+
+func TestExtractFallsBackToRegex(t *testing.T) {
+	s := &source{desc: &Descriptor{ResultPath: "data.subdomains", Regex: `([a-z0-9.-]+\.example\.com)`}, regex: regexp.MustCompile(`([a-z0-9.-]+\.example\.com)`)}
+
+	names, err := s.extract("not json, but a.example.com is in here")
+	if err != nil {
+		t.Fatalf("extract() error = %v", err)
+	}
+	if len(names) != 1 || names[0] != "a.example.com" {
+		t.Fatalf("extract() = %v", names)
+	}
+}
+
+func TestValuesAtPathMissingSegment(t *testing.T) {
+	doc := map[string]interface{}{"data": map[string]interface{}{}}
+
+	if _, err := valuesAtPath(doc, []string{"data", "subdomains"}); err == nil {
+		t.Fatal("valuesAtPath() error = nil, want an error for the missing segment")
+	}
+}
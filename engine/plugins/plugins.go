@@ -0,0 +1,51 @@
+// Copyright © by Jeff Foley 2017-2025. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package plugins assembles the et.Plugin set the engine registers at
+// startup: the hand-written API plugins plus any YAML-described generic
+// sources found in a descriptor directory.
+package plugins
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/owasp-amass/amass/v5/engine/plugins/api"
+	"github.com/owasp-amass/amass/v5/engine/plugins/api/generic"
+	"github.com/owasp-amass/amass/v5/engine/plugins/support"
+	et "github.com/owasp-amass/amass/v5/engine/types"
+)
+
+// DefaultSummaryInterval is how often StartMetrics logs a source health
+// snapshot when the caller has no stronger opinion.
+const DefaultSummaryInterval = 5 * time.Minute
+
+// StartMetrics builds the MetricsManager that All's plugins report through,
+// mounts its Prometheus scrape endpoint on mux at "/metrics", and starts its
+// periodic slog summary. The returned stop function halts the summary
+// logger; callers typically defer it for the lifetime of the registry.
+func StartMetrics(log *slog.Logger, mux *http.ServeMux, summaryInterval time.Duration) (et.MetricsManager, func()) {
+	m := support.NewMetricsManager(nil)
+	mux.Handle("/metrics", m.Handler())
+	return m, m.StartSummaryLogger(log, summaryInterval)
+}
+
+// All returns the plugins the engine registers at startup: the built-in API
+// plugins, followed by one et.Plugin per descriptor found in descriptorDir. A
+// descriptor directory that does not exist yet is not an error; it simply
+// contributes no additional plugins. metrics is typically the manager
+// returned by StartMetrics.
+func All(descriptorDir string, metrics et.MetricsManager) ([]et.Plugin, error) {
+	registered := []et.Plugin{
+		api.NewDomainsProject(metrics),
+	}
+
+	generated, err := generic.LoadPlugins(descriptorDir)
+	if err != nil && len(generated) == 0 {
+		return registered, err
+	}
+
+	return append(registered, generated...), err
+}
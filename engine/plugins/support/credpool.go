@@ -0,0 +1,197 @@
+// Copyright © by Jeff Foley 2017-2025. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/owasp-amass/amass/v5/config"
+	"github.com/owasp-amass/amass/v5/internal/net/http"
+)
+
+// defaultCooldown is used to park a credential when a response carries no
+// Retry-After header of its own.
+const defaultCooldown = 15 * time.Minute
+
+// credState tracks the rotation and quota state of a single credential.
+type credState struct {
+	cred      *config.Credential
+	remaining int
+	resetAt   time.Time
+	cooldown  time.Time
+}
+
+// usable reports whether the credential can be used at t, given its
+// cooldown and any previously observed quota.
+func (s *credState) usable(t time.Time) bool {
+	if s.cooldown.After(t) {
+		return false
+	}
+	if s.remaining == 0 && s.resetAt.After(t) {
+		return false
+	}
+	return true
+}
+
+// CredentialPool round-robins across the credentials configured for a data
+// source, tracks per-key quota from X-RateLimit-* response headers, and
+// parks a key that fails authorization or hits its rate limit until a
+// cooldown derived from Retry-After (or defaultCooldown) elapses. Plugins
+// that authenticate with more than one key should use a CredentialPool
+// instead of looping over config.DataSource.Creds themselves.
+type CredentialPool struct {
+	sync.Mutex
+	source string
+	log    *slog.Logger
+	states []*credState
+	next   int
+}
+
+// NewCredentialPool builds a CredentialPool from the credentials configured
+// for source. Credentials missing a username or password are skipped.
+func NewCredentialPool(source string, creds []*config.Credential, log *slog.Logger) *CredentialPool {
+	cp := &CredentialPool{source: source, log: log}
+
+	for _, cr := range creds {
+		if cr == nil || cr.Username == "" || cr.Password == "" {
+			continue
+		}
+		cp.states = append(cp.states, &credState{cred: cr})
+	}
+	return cp
+}
+
+// Len returns the number of credentials registered with the pool, usable or
+// not. Callers should treat this as the upper bound on attempts per query,
+// since Next can keep returning a usable credential indefinitely (e.g. while
+// a single key round-trips fine) and is not itself a retry budget.
+func (cp *CredentialPool) Len() int {
+	cp.Lock()
+	defer cp.Unlock()
+	return len(cp.states)
+}
+
+// Next returns the next usable credential in round-robin order, skipping
+// keys that are currently in cooldown or out of quota. The second return
+// value is false when no credential is currently usable.
+func (cp *CredentialPool) Next() (*config.Credential, bool) {
+	cp.Lock()
+	defer cp.Unlock()
+
+	num := len(cp.states)
+	if num == 0 {
+		return nil, false
+	}
+
+	now := time.Now()
+	for i := 0; i < num; i++ {
+		idx := (cp.next + i) % num
+		st := cp.states[idx]
+
+		if !st.usable(now) {
+			continue
+		}
+		if !st.cooldown.IsZero() {
+			cp.log.Info("credential reactivated", "source", cp.source)
+			st.cooldown = time.Time{}
+		}
+
+		cp.next = (idx + 1) % num
+		return st.cred, true
+	}
+
+	return nil, false
+}
+
+// Update records the outcome of a request made with cred, refreshing its
+// quota from the response headers and parking it when the request failed in
+// a way that is unlikely to succeed again immediately: an authorization or
+// rate-limit status, a server error, or a transport-level error (timeout,
+// connection refused, and the like).
+func (cp *CredentialPool) Update(cred *config.Credential, resp *http.Response, err error) {
+	cp.Lock()
+	defer cp.Unlock()
+
+	st := cp.find(cred)
+	if st == nil {
+		return
+	}
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+
+	if err != nil || status == 401 || status == 403 || status == 429 || status >= 500 {
+		cooldown := defaultCooldown
+		if resp != nil {
+			if ra := retryAfter(resp.Header); ra > 0 {
+				cooldown = ra
+			}
+		}
+
+		st.cooldown = time.Now().Add(cooldown)
+		cp.log.Warn("credential exhausted", "source", cp.source, "status", status, "cooldown", cooldown.String())
+		return
+	}
+
+	if resp != nil {
+		if remaining, reset, ok := rateLimitHeaders(resp.Header); ok {
+			st.remaining = remaining
+			st.resetAt = reset
+		}
+	}
+}
+
+func (cp *CredentialPool) find(cred *config.Credential) *credState {
+	for _, st := range cp.states {
+		if st.cred == cred {
+			return st
+		}
+	}
+	return nil
+}
+
+// retryAfter parses the Retry-After header, which may be either a number of
+// seconds or an HTTP-date, per RFC 7231.
+func retryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := time.Parse(time.RFC1123, v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// rateLimitHeaders parses the de facto standard X-RateLimit-Remaining and
+// X-RateLimit-Reset headers.
+func rateLimitHeaders(h http.Header) (int, time.Time, bool) {
+	rem := h.Get("X-RateLimit-Remaining")
+	if rem == "" {
+		return 0, time.Time{}, false
+	}
+
+	remaining, err := strconv.Atoi(rem)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+
+	var reset time.Time
+	if rs := h.Get("X-RateLimit-Reset"); rs != "" {
+		if secs, err := strconv.Atoi(rs); err == nil {
+			reset = time.Unix(int64(secs), 0)
+		}
+	}
+
+	return remaining, reset, true
+}
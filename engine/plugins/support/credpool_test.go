@@ -0,0 +1,153 @@
+// Copyright © by Jeff Foley 2017-2025. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"log/slog"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/owasp-amass/amass/v5/config"
+	"github.com/owasp-amass/amass/v5/internal/net/http"
+)
+
+func testCreds(n int) []*config.Credential {
+	creds := make([]*config.Credential, n)
+	for i := range creds {
+		creds[i] = &config.Credential{Username: string(rune('a' + i)), Password: "pw"}
+	}
+	return creds
+}
+
+func TestCredentialPoolRoundRobin(t *testing.T) {
+	cp := NewCredentialPool("test", testCreds(3), slog.Default())
+
+	if l := cp.Len(); l != 3 {
+		t.Fatalf("Len() = %d, want 3", l)
+	}
+
+	var order []string
+	for i := 0; i < 6; i++ {
+		cr, ok := cp.Next()
+		if !ok {
+			t.Fatalf("Next() returned false on attempt %d", i)
+		}
+		order = append(order, cr.Username)
+	}
+
+	for i := 0; i < 3; i++ {
+		if order[i] != order[i+3] {
+			t.Fatalf("round-robin did not repeat after a full cycle: %v", order)
+		}
+	}
+}
+
+func TestCredentialPoolSkipsParkedCredential(t *testing.T) {
+	creds := testCreds(2)
+	cp := NewCredentialPool("test", creds, slog.Default())
+
+	first, ok := cp.Next()
+	if !ok {
+		t.Fatal("Next() = false on a fresh pool")
+	}
+	cp.Update(first, &http.Response{StatusCode: 401}, nil)
+
+	for i := 0; i < 3; i++ {
+		cr, ok := cp.Next()
+		if !ok {
+			t.Fatalf("Next() = false while one credential remains usable (attempt %d)", i)
+		}
+		if cr == first {
+			t.Fatalf("Next() returned the parked credential %q", cr.Username)
+		}
+	}
+}
+
+func TestCredentialPoolAllParkedReturnsNotOK(t *testing.T) {
+	creds := testCreds(2)
+	cp := NewCredentialPool("test", creds, slog.Default())
+
+	for _, cr := range creds {
+		cp.Update(cr, &http.Response{StatusCode: 429}, nil)
+	}
+
+	if _, ok := cp.Next(); ok {
+		t.Fatal("Next() = true with every credential parked")
+	}
+}
+
+func TestCredentialPoolReactivatesAfterCooldown(t *testing.T) {
+	creds := testCreds(1)
+	cp := NewCredentialPool("test", creds, slog.Default())
+
+	cr, _ := cp.Next()
+	// Retry-After of 0 seconds should still park the credential until the
+	// cooldown's instant has passed, not forever.
+	cp.Update(cr, &http.Response{StatusCode: 503, Header: http.Header{"Retry-After": []string{"0"}}}, nil)
+
+	if _, ok := cp.Next(); ok {
+		t.Fatal("Next() = true immediately after parking, want false")
+	}
+
+	cp.Lock()
+	cp.states[0].cooldown = time.Now().Add(-time.Second)
+	cp.Unlock()
+
+	if _, ok := cp.Next(); !ok {
+		t.Fatal("Next() = false after the cooldown elapsed")
+	}
+}
+
+func TestCredentialPoolUpdateTracksQuota(t *testing.T) {
+	creds := testCreds(1)
+	cp := NewCredentialPool("test", creds, slog.Default())
+
+	cr, _ := cp.Next()
+	reset := time.Now().Add(time.Hour).Unix()
+	cp.Update(cr, &http.Response{
+		StatusCode: 200,
+		Header: http.Header{
+			"X-RateLimit-Remaining": []string{"0"},
+			"X-RateLimit-Reset":     []string{strconv.FormatInt(reset, 10)},
+		},
+	}, nil)
+
+	st := cp.find(cr)
+	if st.remaining != 0 {
+		t.Fatalf("remaining = %d, want 0", st.remaining)
+	}
+	if st.resetAt.Unix() != reset {
+		t.Fatalf("resetAt = %d, want %d", st.resetAt.Unix(), reset)
+	}
+}
+
+func TestCredentialPoolEmptyPool(t *testing.T) {
+	cp := NewCredentialPool("test", nil, slog.Default())
+
+	if l := cp.Len(); l != 0 {
+		t.Fatalf("Len() = %d, want 0", l)
+	}
+	if _, ok := cp.Next(); ok {
+		t.Fatal("Next() = true on an empty pool")
+	}
+}
+
+func TestRetryAfterParsesSecondsAndDate(t *testing.T) {
+	h := http.Header{"Retry-After": []string{"120"}}
+	if d := retryAfter(h); d != 120*time.Second {
+		t.Fatalf("retryAfter(seconds) = %s, want 2m0s", d)
+	}
+
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC1123)
+	h = http.Header{"Retry-After": []string{future}}
+	if d := retryAfter(h); d <= 0 {
+		t.Fatalf("retryAfter(date) = %s, want > 0", d)
+	}
+
+	if d := retryAfter(http.Header{}); d != 0 {
+		t.Fatalf("retryAfter(missing) = %s, want 0", d)
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright © by Jeff Foley 2017-2025. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	et "github.com/owasp-amass/amass/v5/engine/types"
+)
+
+// sourceMetrics holds the per-plugin counters tracked by MetricsManager.
+// All fields are only ever touched while MetricsManager's mutex is held.
+type sourceMetrics struct {
+	requests      int64
+	statusCodes   map[int]int64
+	bytesReceived int64
+	unmarshalErrs int64
+	discovered    int64
+	inScope       int64
+	cacheHits     int64
+	liveQueries   int64
+	authFailures  map[string]int64
+	rateLimitWait time.Duration
+}
+
+// MetricsManager aggregates per-plugin counters and histograms so operators
+// running long enumerations can see which sources are productive and which
+// are wasting quota, without grepping logs. It implements et.MetricsManager
+// so a Registry or Session can expose one without its callers depending on
+// this package directly.
+type MetricsManager struct {
+	mu       sync.Mutex
+	sources  map[string]*sourceMetrics
+	gatherer prometheus.Gatherer
+
+	reqTotal      *prometheus.CounterVec
+	statusTotal   *prometheus.CounterVec
+	bytesTotal    *prometheus.CounterVec
+	unmarshalErr  *prometheus.CounterVec
+	discoveredCtr *prometheus.CounterVec
+	inScopeCtr    *prometheus.CounterVec
+	cacheHitCtr   *prometheus.CounterVec
+	liveQueryCtr  *prometheus.CounterVec
+	authFailCtr   *prometheus.CounterVec
+	waitHist      *prometheus.HistogramVec
+}
+
+var _ et.MetricsManager = (*MetricsManager)(nil)
+
+// NewMetricsManager builds a MetricsManager and registers its collectors
+// with reg. Passing nil creates a dedicated prometheus.Registry instead of
+// reaching for prometheus.DefaultRegisterer, so callers that build more than
+// one MetricsManager (one per session, for example) never collide on the
+// default registry's global collector set.
+func NewMetricsManager(reg *prometheus.Registry) *MetricsManager {
+	if reg == nil {
+		reg = prometheus.NewRegistry()
+	}
+
+	m := &MetricsManager{
+		sources:  make(map[string]*sourceMetrics),
+		gatherer: reg,
+		reqTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "amass_source_requests_total",
+			Help: "Number of requests issued per data source.",
+		}, []string{"source"}),
+		statusTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "amass_source_http_status_total",
+			Help: "HTTP response status distribution per data source.",
+		}, []string{"source", "status"}),
+		bytesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "amass_source_bytes_received_total",
+			Help: "Bytes received per data source.",
+		}, []string{"source"}),
+		unmarshalErr: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "amass_source_unmarshal_errors_total",
+			Help: "JSON decode failures per data source.",
+		}, []string{"source"}),
+		discoveredCtr: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "amass_source_subdomains_discovered_total",
+			Help: "Subdomains discovered per data source.",
+		}, []string{"source"}),
+		inScopeCtr: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "amass_source_subdomains_in_scope_total",
+			Help: "Discovered subdomains that passed scope per data source.",
+		}, []string{"source"}),
+		cacheHitCtr: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "amass_source_cache_hits_total",
+			Help: "TTL-cache hits per data source.",
+		}, []string{"source"}),
+		liveQueryCtr: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "amass_source_live_queries_total",
+			Help: "Live queries (TTL-cache misses) per data source.",
+		}, []string{"source"}),
+		authFailCtr: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "amass_source_auth_failures_total",
+			Help: "Authentication failures per data source and credential key.",
+		}, []string{"source", "key"}),
+		waitHist: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "amass_source_rate_limit_wait_seconds",
+			Help:    "Time spent waiting on a source's rate limiter.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"source"}),
+	}
+
+	reg.MustRegister(m.reqTotal, m.statusTotal, m.bytesTotal, m.unmarshalErr,
+		m.discoveredCtr, m.inScopeCtr, m.cacheHitCtr, m.liveQueryCtr, m.authFailCtr, m.waitHist)
+
+	return m
+}
+
+func (m *MetricsManager) entry(source string) *sourceMetrics {
+	s, found := m.sources[source]
+	if !found {
+		s = &sourceMetrics{
+			statusCodes:  make(map[int]int64),
+			authFailures: make(map[string]int64),
+		}
+		m.sources[source] = s
+	}
+	return s
+}
+
+// RequestIssued records that source sent an HTTP request.
+func (m *MetricsManager) RequestIssued(source string) {
+	m.mu.Lock()
+	m.entry(source).requests++
+	m.mu.Unlock()
+	m.reqTotal.WithLabelValues(source).Inc()
+}
+
+// HTTPStatus records the status code returned to source.
+func (m *MetricsManager) HTTPStatus(source string, code int) {
+	m.mu.Lock()
+	m.entry(source).statusCodes[code]++
+	m.mu.Unlock()
+	m.statusTotal.WithLabelValues(source, strconv.Itoa(code)).Inc()
+}
+
+// BytesReceived adds n to the byte count received from source.
+func (m *MetricsManager) BytesReceived(source string, n int) {
+	m.mu.Lock()
+	m.entry(source).bytesReceived += int64(n)
+	m.mu.Unlock()
+	m.bytesTotal.WithLabelValues(source).Add(float64(n))
+}
+
+// UnmarshalError records a JSON decode failure for source.
+func (m *MetricsManager) UnmarshalError(source string) {
+	m.mu.Lock()
+	m.entry(source).unmarshalErrs++
+	m.mu.Unlock()
+	m.unmarshalErr.WithLabelValues(source).Inc()
+}
+
+// SubdomainsDiscovered adds n to the subdomains discovered by source, prior
+// to scope filtering.
+func (m *MetricsManager) SubdomainsDiscovered(source string, n int) {
+	if n <= 0 {
+		return
+	}
+	m.mu.Lock()
+	m.entry(source).discovered += int64(n)
+	m.mu.Unlock()
+	m.discoveredCtr.WithLabelValues(source).Add(float64(n))
+}
+
+// SubdomainsInScope adds n to the subdomains from source that passed scope.
+func (m *MetricsManager) SubdomainsInScope(source string, n int) {
+	if n <= 0 {
+		return
+	}
+	m.mu.Lock()
+	m.entry(source).inScope += int64(n)
+	m.mu.Unlock()
+	m.inScopeCtr.WithLabelValues(source).Add(float64(n))
+}
+
+// CacheHit records that a check against source was satisfied from the
+// TTL cache instead of a live query.
+func (m *MetricsManager) CacheHit(source string) {
+	m.mu.Lock()
+	m.entry(source).cacheHits++
+	m.mu.Unlock()
+	m.cacheHitCtr.WithLabelValues(source).Inc()
+}
+
+// LiveQuery records that a check against source required a live query.
+func (m *MetricsManager) LiveQuery(source string) {
+	m.mu.Lock()
+	m.entry(source).liveQueries++
+	m.mu.Unlock()
+	m.liveQueryCtr.WithLabelValues(source).Inc()
+}
+
+// AuthFailure records an authentication or rate-limit failure for the
+// credential identified by key (e.g. its username), so operators can tell
+// which specific key is bad rather than only that source as a whole had a
+// failure.
+func (m *MetricsManager) AuthFailure(source, key string) {
+	m.mu.Lock()
+	m.entry(source).authFailures[key]++
+	m.mu.Unlock()
+	m.authFailCtr.WithLabelValues(source, key).Inc()
+}
+
+// RateLimitWait records how long source's caller waited on its rate limiter.
+func (m *MetricsManager) RateLimitWait(source string, d time.Duration) {
+	m.mu.Lock()
+	m.entry(source).rateLimitWait += d
+	m.mu.Unlock()
+	m.waitHist.WithLabelValues(source).Observe(d.Seconds())
+}
+
+// Handler returns the Prometheus scrape endpoint for this MetricsManager,
+// serving only the collectors registered with the Registry passed to
+// NewMetricsManager.
+func (m *MetricsManager) Handler() http.Handler {
+	return promhttp.HandlerFor(m.gatherer, promhttp.HandlerOpts{})
+}
+
+// StartSummaryLogger logs a snapshot of every source's counters to log every
+// interval, until the returned stop function is called. stop returns only
+// after the logging goroutine has exited, so nothing is logged after it.
+func (m *MetricsManager) StartSummaryLogger(log *slog.Logger, interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	exited := make(chan struct{})
+
+	go func() {
+		defer close(exited)
+		for {
+			select {
+			case <-ticker.C:
+				m.logSummary(log)
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-exited
+	}
+}
+
+func (m *MetricsManager) logSummary(log *slog.Logger) {
+	m.mu.Lock()
+	names := make([]string, 0, len(m.sources))
+	for name := range m.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s := m.sources[name]
+		var authFailures int64
+		for _, n := range s.authFailures {
+			authFailures += n
+		}
+
+		log.Info("source metrics",
+			"source", name,
+			"requests", s.requests,
+			"bytes_received", s.bytesReceived,
+			"unmarshal_errors", s.unmarshalErrs,
+			"discovered", s.discovered,
+			"in_scope", s.inScope,
+			"cache_hits", s.cacheHits,
+			"live_queries", s.liveQueries,
+			"auth_failures", authFailures,
+			"rate_limit_wait", s.rateLimitWait.String(),
+		)
+
+		for key, n := range s.authFailures {
+			if n > 0 {
+				log.Warn("source credential failing", "source", name, "key", key, "auth_failures", n)
+			}
+		}
+	}
+	m.mu.Unlock()
+}
@@ -0,0 +1,84 @@
+// Copyright © by Jeff Foley 2017-2025. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsManagerHandlerServesRegisteredCounters(t *testing.T) {
+	m := NewMetricsManager(nil)
+	m.RequestIssued("test-source")
+	m.HTTPStatus("test-source", 200)
+
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := rr.Body.String()
+	if !strings.Contains(body, "amass_source_requests_total") {
+		t.Fatalf("scrape output missing amass_source_requests_total:\n%s", body)
+	}
+	if !strings.Contains(body, `source="test-source"`) {
+		t.Fatalf("scrape output missing source label:\n%s", body)
+	}
+}
+
+func TestMetricsManagerTwoInstancesDoNotCollide(t *testing.T) {
+	// NewMetricsManager must not panic from MustRegister colliding with a
+	// shared default registry when more than one instance is built.
+	a := NewMetricsManager(nil)
+	b := NewMetricsManager(nil)
+
+	a.RequestIssued("source-a")
+	b.RequestIssued("source-b")
+
+	rrA := httptest.NewRecorder()
+	a.Handler().ServeHTTP(rrA, httptest.NewRequest("GET", "/metrics", nil))
+	if strings.Contains(rrA.Body.String(), "source-b") {
+		t.Fatal("a's scrape endpoint exposed b's metrics")
+	}
+}
+
+func TestMetricsManagerAuthFailureTracksPerKey(t *testing.T) {
+	m := NewMetricsManager(nil)
+	m.AuthFailure("test-source", "key-1")
+	m.AuthFailure("test-source", "key-1")
+	m.AuthFailure("test-source", "key-2")
+
+	m.mu.Lock()
+	entry := m.sources["test-source"]
+	m.mu.Unlock()
+
+	if entry.authFailures["key-1"] != 2 {
+		t.Fatalf("key-1 failures = %d, want 2", entry.authFailures["key-1"])
+	}
+	if entry.authFailures["key-2"] != 1 {
+		t.Fatalf("key-2 failures = %d, want 1", entry.authFailures["key-2"])
+	}
+}
+
+func TestMetricsManagerStartSummaryLoggerStopsCleanly(t *testing.T) {
+	m := NewMetricsManager(nil)
+	m.RequestIssued("test-source")
+
+	var buf bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&buf, nil))
+
+	stop := m.StartSummaryLogger(log, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	stop()
+
+	if buf.Len() == 0 {
+		t.Fatal("StartSummaryLogger never logged a summary")
+	}
+	if !strings.Contains(buf.String(), "test-source") {
+		t.Fatalf("summary log missing source name:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright © by Jeff Foley 2017-2025. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/owasp-amass/amass/v5/config"
+	et "github.com/owasp-amass/amass/v5/engine/types"
+)
+
+// sessionPoolTTL bounds how long a CredentialPool is kept for a session that
+// has gone quiet. Nothing in et.Registry or et.Session currently calls back
+// a plugin when a session ends, so SessionCredentialPools sweeps idle
+// entries on access instead of relying on such a hook, the same TTL-driven
+// approach TTLStartTime and AssetMonitoredWithinTTL already use for assets.
+const sessionPoolTTL = 30 * time.Minute
+
+// pooledCreds pairs a CredentialPool with the last time it was handed out,
+// so SessionCredentialPools can tell which sessions have gone idle.
+type pooledCreds struct {
+	pool     *CredentialPool
+	lastUsed time.Time
+}
+
+// SessionCredentialPools hands a plugin one CredentialPool per et.Session,
+// keyed on the live Session object. Plugins such as domainsProject and the
+// generic source run for the lifetime of the engine process and see many
+// short-lived sessions, so the pool map must not grow without bound; Get
+// sweeps out any other session's entry that has been idle longer than
+// sessionPoolTTL every time it is called.
+type SessionCredentialPools struct {
+	mu      sync.Mutex
+	source  string
+	entries map[et.Session]*pooledCreds
+}
+
+// NewSessionCredentialPools builds a pool-of-pools for a single data source.
+func NewSessionCredentialPools(source string) *SessionCredentialPools {
+	return &SessionCredentialPools{source: source, entries: make(map[et.Session]*pooledCreds)}
+}
+
+// Get returns the CredentialPool for sess, building one from creds the
+// first time sess is seen.
+func (sp *SessionCredentialPools) Get(sess et.Session, creds []*config.Credential, log *slog.Logger) *CredentialPool {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	now := time.Now()
+	for s, e := range sp.entries {
+		if s != sess && now.Sub(e.lastUsed) > sessionPoolTTL {
+			delete(sp.entries, s)
+		}
+	}
+
+	e, found := sp.entries[sess]
+	if !found {
+		e = &pooledCreds{pool: NewCredentialPool(sp.source, creds, log)}
+		sp.entries[sess] = e
+	}
+	e.lastUsed = now
+
+	return e.pool
+}
@@ -0,0 +1,51 @@
+// Copyright © by Jeff Foley 2017-2025. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// MetricsManager aggregates per-plugin request, discovery, and failure
+// counters so operators running long enumerations can see which data
+// sources are productive and which are wasting quota, without grepping
+// logs. A plugin is handed one when it is constructed, so it can report at
+// every observable point without reaching for a package-level global.
+// Registry and Session do not expose a MetricsManager.
+type MetricsManager interface {
+	// RequestIssued records that source sent an HTTP request.
+	RequestIssued(source string)
+	// HTTPStatus records the status code a source's request received.
+	HTTPStatus(source string, code int)
+	// BytesReceived adds n to the byte count received from source.
+	BytesReceived(source string, n int)
+	// UnmarshalError records a JSON decode failure for source.
+	UnmarshalError(source string)
+	// SubdomainsDiscovered adds n to the subdomains source returned,
+	// prior to scope filtering.
+	SubdomainsDiscovered(source string, n int)
+	// SubdomainsInScope adds n to the subdomains from source that passed
+	// scope.
+	SubdomainsInScope(source string, n int)
+	// CacheHit records that a check against source was satisfied from
+	// the TTL cache instead of a live query.
+	CacheHit(source string)
+	// LiveQuery records that a check against source required a live
+	// query.
+	LiveQuery(source string)
+	// AuthFailure records that the credential identified by key failed
+	// authentication or was rate limited for source.
+	AuthFailure(source, key string)
+	// RateLimitWait records how long a source's caller waited on its
+	// rate limiter.
+	RateLimitWait(source string, d time.Duration)
+	// Handler returns the Prometheus scrape endpoint for this manager.
+	Handler() http.Handler
+	// StartSummaryLogger logs a snapshot of every source's counters to
+	// log every interval, until the returned stop function is called.
+	StartSummaryLogger(log *slog.Logger, interval time.Duration) (stop func())
+}